@@ -0,0 +1,255 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/canonical/gomaasclient/client"
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceMAASBondInterface() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage a MAAS bond network interface, combining several parent interfaces into a single logical link.",
+		CreateContext: resourceBondInterfaceCreate,
+		ReadContext:   resourceBondInterfaceRead,
+		UpdateContext: resourceBondInterfaceUpdate,
+		DeleteContext: resourceBondInterfaceDelete,
+
+		Schema: map[string]*schema.Schema{
+			"device": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"machine", "device"},
+				Description:  "The identifier (system ID, hostname, or FQDN) of the device with the parent network interfaces. Either `machine` or `device` must be provided.",
+			},
+			"machine": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"machine", "device"},
+				Description:  "The identifier (system ID, hostname, or FQDN) of the machine with the parent network interfaces. Either `machine` or `device` must be provided.",
+			},
+			"parents": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of identifiers (MAC address, name, or ID) of the parent network interfaces to bond together.",
+			},
+			"bond_mode": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "active-backup",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"balance-rr", "active-backup", "balance-xor", "broadcast", "802.3ad", "balance-tlb", "balance-alb"}, false)),
+				Description:      "The bonding mode. Changing this recreates the bond. Defaults to `active-backup`. Valid options are `balance-rr`, `active-backup`, `balance-xor`, `broadcast`, `802.3ad`, `balance-tlb`, and `balance-alb`.",
+			},
+			"lacp_rate": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"slow", "fast"}, false)),
+				Description:      "The LACP rate, only used when `bond_mode` is `802.3ad`. Changing this recreates the bond. Valid options are `slow` and `fast`.",
+			},
+			"xmit_hash_policy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The transmit hash policy used for slave selection, used in `balance-xor`, `802.3ad`, and `balance-tlb` modes. Changing this recreates the bond.",
+			},
+			"miimon": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     100,
+				Description: "The link monitoring frequency, in milliseconds. Changing this recreates the bond. Defaults to `100`.",
+			},
+			"downdelay": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     0,
+				Description: "Number of milliseconds to wait before disabling a slave after a link failure is detected. Changing this recreates the bond. Defaults to `0`.",
+			},
+			"updelay": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     0,
+				Description: "Number of milliseconds to wait before enabling a slave after a link recovery is detected. Changing this recreates the bond. Defaults to `0`.",
+			},
+			"mac_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The MAC address of the bond interface. Defaults to the MAC address of the first parent interface.",
+			},
+			"mtu": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1500,
+				Description: "The MTU of the bond interface. Defaults to `1500`.",
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of tags to assign to the bond interface.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the bond interface.",
+			},
+		},
+	}
+}
+
+func resourceBondInterfaceCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	parentIDs, err := getNetworkInterfaceIDs(client, systemID, d.Get("parents").([]any))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	networkInterface, err := client.NetworkInterfaces.CreateBond(systemID, getBondInterfaceParams(d, parentIDs))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%v", networkInterface.ID))
+
+	return resourceBondInterfaceRead(ctx, d, meta)
+}
+
+func resourceBondInterfaceRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	networkInterface, err := getNetworkInterface(client, systemID, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tfState := map[string]any{
+		"name":        networkInterface.Name,
+		"mac_address": networkInterface.MACAddress,
+		"mtu":         networkInterface.EffectiveMTU,
+		"tags":        networkInterface.Tags,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceBondInterfaceUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	interfaceID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := &entity.NetworkInterfaceUpdateParams{
+		Name:       d.Get("name").(string),
+		MACAddress: d.Get("mac_address").(string),
+		MTU:        d.Get("mtu").(int),
+		Tags:       getStringList(d.Get("tags").([]any)),
+	}
+
+	if _, err := client.NetworkInterface.Update(systemID, interfaceID, params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceBondInterfaceRead(ctx, d, meta)
+}
+
+func resourceBondInterfaceDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	interfaceID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := getNetworkInterface(client, systemID, d.Id()); err != nil {
+		return nil //nolint:nilerr // Interface (and likely the machine) is already gone.
+	}
+
+	if err := client.NetworkInterface.Delete(systemID, interfaceID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getBondInterfaceParams(d *schema.ResourceData, parentIDs []int) *entity.NetworkInterfaceBondParams {
+	return &entity.NetworkInterfaceBondParams{
+		Parents:            parentIDs,
+		Name:               d.Get("name").(string),
+		MACAddress:         d.Get("mac_address").(string),
+		MTU:                d.Get("mtu").(int),
+		Tags:               getStringList(d.Get("tags").([]any)),
+		BondMode:           d.Get("bond_mode").(string),
+		BondLACPRate:       d.Get("lacp_rate").(string),
+		BondXmitHashPolicy: d.Get("xmit_hash_policy").(string),
+		BondMiimon:         d.Get("miimon").(int),
+		BondDownDelay:      d.Get("downdelay").(int),
+		BondUpDelay:        d.Get("updelay").(int),
+	}
+}
+
+// getNetworkInterfaceIDs resolves a list of interface identifiers (MAC
+// address, name, or ID) to their numeric IDs.
+func getNetworkInterfaceIDs(client *client.Client, systemID string, identifiers []any) ([]int, error) {
+	ids := make([]int, 0, len(identifiers))
+
+	for _, identifier := range identifiers {
+		networkInterface, err := getNetworkInterface(client, systemID, identifier.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, networkInterface.ID)
+	}
+
+	return ids, nil
+}
+
+// getStringList converts a schema.TypeList of strings into a []string.
+func getStringList(items []any) []string {
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, item.(string))
+	}
+
+	return list
+}
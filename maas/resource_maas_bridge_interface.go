@@ -0,0 +1,202 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceMAASBridgeInterface() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage a MAAS bridge network interface.",
+		CreateContext: resourceBridgeInterfaceCreate,
+		ReadContext:   resourceBridgeInterfaceRead,
+		UpdateContext: resourceBridgeInterfaceUpdate,
+		DeleteContext: resourceBridgeInterfaceDelete,
+
+		Schema: map[string]*schema.Schema{
+			"device": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"machine", "device"},
+				Description:  "The identifier (system ID, hostname, or FQDN) of the device with the parent network interface. Either `machine` or `device` must be provided.",
+			},
+			"machine": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"machine", "device"},
+				Description:  "The identifier (system ID, hostname, or FQDN) of the machine with the parent network interface. Either `machine` or `device` must be provided.",
+			},
+			"parent": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier (MAC address, name, or ID) of the parent network interface.",
+			},
+			"bridge_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "standard",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"standard", "ovs"}, false)),
+				Description:      "The bridge type. Changing this recreates the bridge. Defaults to `standard`. Valid options are `standard` and `ovs`.",
+			},
+			"bridge_stp": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Boolean value to turn spanning tree protocol on or off. Changing this recreates the bridge. Defaults to `false`.",
+			},
+			"bridge_fd": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     15,
+				Description: "The bridge forward delay, in seconds. Changing this recreates the bridge. Defaults to `15`.",
+			},
+			"mac_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The MAC address of the bridge interface. Defaults to the MAC address of the parent interface.",
+			},
+			"mtu": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1500,
+				Description: "The MTU of the bridge interface. Defaults to `1500`.",
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of tags to assign to the bridge interface.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the bridge interface.",
+			},
+		},
+	}
+}
+
+func resourceBridgeInterfaceCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	parent, err := getNetworkInterface(client, systemID, d.Get("parent").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	networkInterface, err := client.NetworkInterfaces.CreateBridge(systemID, getBridgeInterfaceParams(d, parent.ID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%v", networkInterface.ID))
+
+	return resourceBridgeInterfaceRead(ctx, d, meta)
+}
+
+func resourceBridgeInterfaceRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	networkInterface, err := getNetworkInterface(client, systemID, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tfState := map[string]any{
+		"name":        networkInterface.Name,
+		"mac_address": networkInterface.MACAddress,
+		"mtu":         networkInterface.EffectiveMTU,
+		"tags":        networkInterface.Tags,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceBridgeInterfaceUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	interfaceID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := &entity.NetworkInterfaceUpdateParams{
+		Name:       d.Get("name").(string),
+		MACAddress: d.Get("mac_address").(string),
+		MTU:        d.Get("mtu").(int),
+		Tags:       getStringList(d.Get("tags").([]any)),
+	}
+
+	if _, err := client.NetworkInterface.Update(systemID, interfaceID, params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceBridgeInterfaceRead(ctx, d, meta)
+}
+
+func resourceBridgeInterfaceDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	interfaceID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := getNetworkInterface(client, systemID, d.Id()); err != nil {
+		return nil //nolint:nilerr // Interface (and likely the machine) is already gone.
+	}
+
+	if err := client.NetworkInterface.Delete(systemID, interfaceID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getBridgeInterfaceParams(d *schema.ResourceData, parentID int) *entity.NetworkInterfaceBridgeParams {
+	return &entity.NetworkInterfaceBridgeParams{
+		Parent:     parentID,
+		Name:       d.Get("name").(string),
+		MACAddress: d.Get("mac_address").(string),
+		MTU:        d.Get("mtu").(int),
+		Tags:       getStringList(d.Get("tags").([]any)),
+		BridgeType: d.Get("bridge_type").(string),
+		BridgeSTP:  d.Get("bridge_stp").(bool),
+		BridgeFD:   d.Get("bridge_fd").(int),
+	}
+}
@@ -3,7 +3,10 @@ package maas
 import (
 	"context"
 	"fmt"
+	"log"
+	"os"
 	"strconv"
+	"time"
 
 	"github.com/canonical/gomaasclient/client"
 	"github.com/canonical/gomaasclient/entity"
@@ -13,6 +16,46 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// Values for the unlink_policy schema attribute, controlling how unlinkSubnet
+// handles a machine that isn't in one of the states where MAAS allows
+// unlinking outright (New, Ready, Allocated, Broken).
+const (
+	unlinkPolicySafe    = "safe"
+	unlinkPolicyRelease = "release"
+	unlinkPolicyForce   = "force"
+
+	machineSettlePollInterval = 5 * time.Second
+
+	// Environment variables let operators set an org-wide default for
+	// unlink_policy/deploy_wait_timeout without repeating it on every
+	// maas_network_interface_link(s) instance; the schema attribute always
+	// overrides them.
+	envUnlinkPolicy      = "MAAS_UNLINK_POLICY"
+	envDeployWaitTimeout = "MAAS_DEPLOY_WAIT_TIMEOUT"
+)
+
+// unlinkPolicyDefault resolves the provider-wide default for unlink_policy,
+// falling back to "safe" when MAAS_UNLINK_POLICY isn't set.
+func unlinkPolicyDefault() (any, error) {
+	if v := os.Getenv(envUnlinkPolicy); v != "" {
+		return v, nil
+	}
+
+	return unlinkPolicySafe, nil
+}
+
+// deployWaitTimeoutDefault resolves the provider-wide default for
+// deploy_wait_timeout, falling back to 0 (no wait) when
+// MAAS_DEPLOY_WAIT_TIMEOUT isn't set.
+func deployWaitTimeoutDefault() (any, error) {
+	v := os.Getenv(envDeployWaitTimeout)
+	if v == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(v)
+}
+
 func resourceMAASNetworkInterfaceLink() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Provides a resource to manage network configuration on a network interface.",
@@ -69,6 +112,19 @@ func resourceMAASNetworkInterfaceLink() *schema.Resource {
 				ForceNew:    true,
 				Description: "The identifier (CIDR or ID) of the subnet to be connected.",
 			},
+			"unlink_policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      unlinkPolicyDefault,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{unlinkPolicySafe, unlinkPolicyRelease, unlinkPolicyForce}, false)),
+				Description:      "Controls how the provider unlinks the subnet when the machine isn't in a state where MAAS allows it directly (`New`, `Ready`, `Allocated`, `Broken`). Defaults to `safe`, or the value of the `MAAS_UNLINK_POLICY` environment variable if set, so operators can set an org-wide default without repeating it on every resource. Valid options are:\n\t* `safe` - Fail with a diagnostic rather than aborting an in-flight operation or releasing the machine.\n\t* `release` - Abort any in-flight operation and release the machine before unlinking.\n\t* `force` - Skip releasing the machine and attempt to unlink directly, tolerating the API error if MAAS rejects it.",
+			},
+			"deploy_wait_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: deployWaitTimeoutDefault,
+				Description: "Number of seconds to wait for a machine in a transitional state (e.g. `Deploying`, `Commissioning`) to settle into a terminal state before applying `unlink_policy`. Defaults to `0` (no wait), or the value of the `MAAS_DEPLOY_WAIT_TIMEOUT` environment variable if set.",
+			},
 		},
 	}
 }
@@ -91,7 +147,9 @@ func resourceNetworkInterfaceLinkCreate(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
-	link, err := createNetworkInterfaceLink(client, systemID, networkInterface, getNetworkInterfaceLinkParams(d, subnet.ID))
+	policy, waitTimeout := getUnlinkPolicy(d)
+
+	link, err := createNetworkInterfaceLink(client, systemID, networkInterface, getNetworkInterfaceLinkParams(d, subnet.ID), policy, waitTimeout)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -187,14 +245,22 @@ func resourceNetworkInterfaceLinkDelete(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
+	policy, waitTimeout := getUnlinkPolicy(d)
+
 	// Delete the network interface link
-	if err := deleteNetworkInterfaceLink(client, systemID, networkInterface.ID, linkID); err != nil {
+	if err := deleteNetworkInterfaceLink(client, systemID, networkInterface.ID, linkID, policy, waitTimeout); err != nil {
 		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
+// getUnlinkPolicy reads the unlink_policy and deploy_wait_timeout attributes
+// shared by the network-interface-link resources.
+func getUnlinkPolicy(d *schema.ResourceData) (string, time.Duration) {
+	return d.Get("unlink_policy").(string), time.Duration(d.Get("deploy_wait_timeout").(int)) * time.Second
+}
+
 func getNetworkInterfaceLinkParams(d *schema.ResourceData, subnetID int) *entity.NetworkInterfaceLinkParams {
 	return &entity.NetworkInterfaceLinkParams{
 		Subnet:         subnetID,
@@ -204,10 +270,10 @@ func getNetworkInterfaceLinkParams(d *schema.ResourceData, subnetID int) *entity
 	}
 }
 
-func createNetworkInterfaceLink(client *client.Client, machineSystemID string, networkInterface *entity.NetworkInterface, params *entity.NetworkInterfaceLinkParams) (*entity.NetworkInterfaceLink, error) {
+func createNetworkInterfaceLink(client *client.Client, machineSystemID string, networkInterface *entity.NetworkInterface, params *entity.NetworkInterfaceLinkParams, policy string, waitTimeout time.Duration) (*entity.NetworkInterfaceLink, error) {
 	// Clear existing links
 	for _, link := range networkInterface.Links {
-		err := unlinkSubnet(client, machineSystemID, networkInterface.ID, link.ID)
+		err := unlinkSubnet(client, machineSystemID, networkInterface.ID, link.ID, policy, waitTimeout)
 		if err != nil {
 			return nil, err
 		}
@@ -237,40 +303,14 @@ func getNetworkInterfaceLink(client *client.Client, machineSystemID string, netw
 	return nil, fmt.Errorf("cannot find link (%v) on the network interface (%v) from machine (%s)", linkID, networkInterfaceID, machineSystemID)
 }
 
-func deleteNetworkInterfaceLink(client *client.Client, machineSystemID string, networkInterfaceID int, linkID int) error {
-	return unlinkSubnet(client, machineSystemID, networkInterfaceID, linkID)
+func deleteNetworkInterfaceLink(client *client.Client, machineSystemID string, networkInterfaceID int, linkID int, policy string, waitTimeout time.Duration) error {
+	return unlinkSubnet(client, machineSystemID, networkInterfaceID, linkID, policy, waitTimeout)
 }
 
-func unlinkSubnet(client *client.Client, machineSystemID string, networkInterfaceID int, linkID int) error {
-	// Interfaces may only be unlinked from subnets when the machine(s) they are attached to are in valid states.
-	// Unlinking an interface when the machine is not in a valid state is not allowed and can result errors.
-	// To address this, we introduce this handler whose job is to ensure that the machine is in a valid state before unlinking.
-	//
-	// Valid states include: New, Ready, Allocated, Broken. In other states we need to handle this operation differently,
-	// for example in transitional states compared to non-transitional states (for instance, Deploying vs. Deployed).
-	//
-	// There are four scenarios to consider:
-	// 1. The machine no longer exists. Unlinking should result in a no-op.
-	// 2. The machine is in a valid state. Unlinking is allowed.
-	// 3. The machine is in a transitional state. TBD.
-	// 4. The machine is in a non-transitional state. TBD.
-
-	// Obtain the state of the machine so we can ascertain how to handle proper unlinking
-	machine, err := client.Machine.Get(machineSystemID)
-	if err != nil {
-		return nil //nolint:nilerr // The machine doesn't or no longer exists, so this is a no-op
-	}
-
-	switch machine.Status {
-	// Valid states
-	case node.StatusNew, node.StatusReady, node.StatusAllocated, node.StatusBroken:
-		// This is the valid case where unlinking is straight-forward and allowed
-		_, err = client.NetworkInterface.UnlinkSubnet(machineSystemID, networkInterfaceID, linkID)
-		if err != nil {
-			return err
-		}
-
-	// Transitional states
+// isTransitionalMachineStatus reports whether a machine's status is expected
+// to settle into a terminal status on its own, e.g. Deploying -> Deployed.
+func isTransitionalMachineStatus(status node.Status) bool {
+	switch status {
 	case
 		node.StatusCommissioning,
 		node.StatusDeploying,
@@ -279,55 +319,107 @@ func unlinkSubnet(client *client.Client, machineSystemID string, networkInterfac
 		node.StatusEnteringRescureMode,
 		node.StatusExitingRescueMode,
 		node.StatusTesting:
-		eventLogMsg := fmt.Sprintf("Terraform requested machine %s be destroyed. Aborting current operation...", machine.SystemID)
+		return true
+	default:
+		return false
+	}
+}
 
-		machine, err = client.Machine.Abort(machine.SystemID, eventLogMsg)
-		if err != nil {
-			return err
-		}
+// waitForMachineSettled polls the machine until it leaves a transitional
+// status or waitTimeout elapses, returning the last observed machine. This
+// lets a machine that is e.g. commissioning or releasing reach a state where
+// unlinking is allowed (such as Ready) instead of immediately failing the
+// apply; a machine that is mid-deploy settles into Deployed, which
+// unlink_policy="safe" still refuses.
+func waitForMachineSettled(client *client.Client, machineSystemID string, waitTimeout time.Duration) (*entity.Machine, error) {
+	deadline := time.Now().Add(waitTimeout)
+
+	machine, err := client.Machine.Get(machineSystemID)
+	if err != nil {
+		return nil, err
+	}
 
-		releaseParams := &entity.MachineReleaseParams{}
+	for isTransitionalMachineStatus(machine.Status) && time.Now().Before(deadline) {
+		time.Sleep(machineSettlePollInterval)
 
-		_, err = client.Machine.Release(machine.SystemID, releaseParams)
+		machine, err = client.Machine.Get(machineSystemID)
 		if err != nil {
-			return err
+			return nil, err
 		}
+	}
 
-		_, err = client.NetworkInterface.UnlinkSubnet(machineSystemID, networkInterfaceID, linkID)
+	return machine, nil
+}
+
+// releaseAndUnlinkSubnet reproduces the provider's original (pre unlink_policy)
+// behavior: abort any in-flight operation on a transitional machine, release
+// the machine, and unlink. This is what unlink_policy="release" opts into.
+func releaseAndUnlinkSubnet(client *client.Client, machine *entity.Machine, networkInterfaceID int, linkID int) error {
+	if isTransitionalMachineStatus(machine.Status) {
+		eventLogMsg := fmt.Sprintf("Terraform requested machine %s be destroyed. Aborting current operation...", machine.SystemID)
+
+		aborted, err := client.Machine.Abort(machine.SystemID, eventLogMsg)
 		if err != nil {
 			return err
 		}
 
-	// Non-transitional states
-	case
-		node.StatusFailedCommissioning,
-		node.StatusMissing,
-		node.StatusReserved,
-		node.StatusDeployed,
-		node.StatusRetired,
-		node.StatusFailedDeployment,
-		node.StatusFailedReleasing,
-		node.StatusFailedDiskErasing,
-		node.StatusRescueMode,
-		node.StatusFailedEnteringRescueMode,
-		node.StatusFailedExitingRescueMode,
-		node.StatusFailedTesting:
-		releaseParams := &entity.MachineReleaseParams{}
-
-		_, err = client.Machine.Release(machine.SystemID, releaseParams)
+		machine = aborted
+	}
+
+	if _, err := client.Machine.Release(machine.SystemID, &entity.MachineReleaseParams{}); err != nil {
+		return err
+	}
+
+	_, err := client.NetworkInterface.UnlinkSubnet(machine.SystemID, networkInterfaceID, linkID)
+
+	return err
+}
+
+func unlinkSubnet(client *client.Client, machineSystemID string, networkInterfaceID int, linkID int, policy string, waitTimeout time.Duration) error {
+	// Interfaces may only be unlinked from subnets when the machine(s) they are attached to are in valid states
+	// (New, Ready, Allocated, Broken). Unlinking outside those states requires aborting/releasing the machine first,
+	// a destructive side effect that running `terraform destroy` on a link resource shouldn't trigger silently.
+	// unlink_policy controls how we handle that case; deploy_wait_timeout gives a transitional machine (e.g.
+	// Deploying) a chance to settle into a terminal state before the policy is applied.
+
+	// Obtain the state of the machine so we can ascertain how to handle proper unlinking
+	machine, err := client.Machine.Get(machineSystemID)
+	if err != nil {
+		return nil //nolint:nilerr // The machine doesn't or no longer exists, so this is a no-op
+	}
+
+	if waitTimeout > 0 && isTransitionalMachineStatus(machine.Status) {
+		machine, err = waitForMachineSettled(client, machineSystemID, waitTimeout)
 		if err != nil {
 			return err
 		}
+	}
 
+	switch machine.Status {
+	// Valid states: unlinking is straightforward and allowed regardless of policy.
+	case node.StatusNew, node.StatusReady, node.StatusAllocated, node.StatusBroken:
 		_, err = client.NetworkInterface.UnlinkSubnet(machineSystemID, networkInterfaceID, linkID)
-		if err != nil {
-			return err
-		}
+
+		return err
 
 	default:
-		// node.StatusDefault is left over
-		return fmt.Errorf("cannot unlink subnet from machine in status %v", machine.Status)
-	}
+		switch policy {
+		case unlinkPolicyForce:
+			log.Printf("[WARN] maas_network_interface_link: unlink_policy=force, unlinking from machine %s in status %v without releasing it", machineSystemID, machine.Status)
 
-	return nil
+			if _, err := client.NetworkInterface.UnlinkSubnet(machineSystemID, networkInterfaceID, linkID); err != nil {
+				log.Printf("[WARN] maas_network_interface_link: unlink_policy=force, ignoring unlink error for machine %s: %s", machineSystemID, err)
+			}
+
+			return nil
+
+		case unlinkPolicyRelease:
+			log.Printf("[WARN] maas_network_interface_link: unlink_policy=release, releasing machine %s (status %v) to unlink the subnet", machineSystemID, machine.Status)
+
+			return releaseAndUnlinkSubnet(client, machine, networkInterfaceID, linkID)
+
+		default:
+			return fmt.Errorf("refusing to unlink subnet from machine %s in status %v: the machine is not in a safe state (New, Ready, Allocated, Broken); set unlink_policy to \"release\" or \"force\" to override, or raise deploy_wait_timeout to wait for a transitional machine to settle", machineSystemID, machine.Status)
+		}
+	}
 }
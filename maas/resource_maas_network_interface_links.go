@@ -0,0 +1,341 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/gomaasclient/client"
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceMAASNetworkInterfaceLinks() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage the complete set of subnet links attached to a network interface. Unlike `maas_network_interface_link`, which manages a single link and tears down any others when it is created, this resource reconciles the full set of `link` blocks against the interface's current links, so a management `AUTO` link and one or more `STATIC` aliases can coexist.",
+		CreateContext: resourceNetworkInterfaceLinksCreate,
+		ReadContext:   resourceNetworkInterfaceLinksRead,
+		UpdateContext: resourceNetworkInterfaceLinksUpdate,
+		DeleteContext: resourceNetworkInterfaceLinksDelete,
+
+		Schema: map[string]*schema.Schema{
+			"device": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"machine", "device"},
+				Description:  "The identifier (system ID, hostname, or FQDN) of the device with the network interface. Either `machine` or `device` must be provided.",
+			},
+			"machine": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"machine", "device"},
+				Description:  "The identifier (system ID, hostname, or FQDN) of the machine with the network interface. Either `machine` or `device` must be provided.",
+			},
+			"network_interface": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier (MAC address, name, or ID) of the network interface.",
+			},
+			"link": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Set:         resourceNetworkInterfaceLinksHash,
+				Description: "A subnet link to maintain on the network interface. Can be specified multiple times to attach several concurrent links. At most one `link` may set `default_gateway` to `true`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subnet": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The identifier (CIDR or ID) of the subnet to be connected.",
+						},
+						"mode": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "AUTO",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"AUTO", "DHCP", "STATIC", "LINK_UP"}, false)),
+							Description:      "Connection mode to subnet. It defaults to `AUTO`. Valid options are:\n\t* `AUTO` - Random static IP address from the subnet.\n\t* `DHCP` - IP address from the DHCP on the given subnet.\n\t* `STATIC` - Use `ip_address` as static IP address.\n\t* `LINK_UP` - Bring the interface up only on the given subnet. No IP address will be assigned.",
+						},
+						"ip_address": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Computed:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+							Description:      "Valid IP address (from the given subnet) to be configured on the network interface. Only used when `mode` is set to `STATIC`.",
+						},
+						"default_gateway": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Boolean value. When enabled, it sets the subnet gateway IP address as the default gateway for the machine the interface belongs to. This option can only be used with the `AUTO` and `STATIC` modes, and only when the resource is addressed by `machine` (MAAS tracks the default gateway at the machine level, so there's no equivalent for a `device`). Defaults to `false`.",
+						},
+					},
+				},
+			},
+			"unlink_policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      unlinkPolicyDefault,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{unlinkPolicySafe, unlinkPolicyRelease, unlinkPolicyForce}, false)),
+				Description:      "Controls how the provider unlinks a subnet when the machine isn't in a state where MAAS allows it directly (`New`, `Ready`, `Allocated`, `Broken`). Defaults to `safe`, or the value of the `MAAS_UNLINK_POLICY` environment variable if set, so operators can set an org-wide default without repeating it on every resource. Valid options are:\n\t* `safe` - Fail with a diagnostic rather than aborting an in-flight operation or releasing the machine.\n\t* `release` - Abort any in-flight operation and release the machine before unlinking.\n\t* `force` - Skip releasing the machine and attempt to unlink directly, tolerating the API error if MAAS rejects it.",
+			},
+			"deploy_wait_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: deployWaitTimeoutDefault,
+				Description: "Number of seconds to wait for a machine in a transitional state (e.g. `Deploying`, `Commissioning`) to settle into a terminal state before applying `unlink_policy`. Defaults to `0` (no wait), or the value of the `MAAS_DEPLOY_WAIT_TIMEOUT` environment variable if set.",
+			},
+		},
+	}
+}
+
+// resourceNetworkInterfaceLinksHash hashes a link block by the subnet it targets,
+// so that reordering links (or MAAS reordering them in its response) doesn't
+// produce a spurious diff.
+func resourceNetworkInterfaceLinksHash(v any) int {
+	link := v.(map[string]any)
+
+	return schema.HashString(fmt.Sprintf("%v", link["subnet"]))
+}
+
+func resourceNetworkInterfaceLinksCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	networkInterface, err := getNetworkInterface(client, systemID, d.Get("network_interface").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%v", networkInterface.ID))
+
+	if diags := reconcileNetworkInterfaceLinks(client, systemID, networkInterface, d); diags != nil {
+		return diags
+	}
+
+	return resourceNetworkInterfaceLinksRead(ctx, d, meta)
+}
+
+func resourceNetworkInterfaceLinksRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	networkInterface, err := getNetworkInterface(client, systemID, d.Get("network_interface").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// MAAS tracks the default gateway at the machine level (keyed by address
+	// family), not on the link itself, so we can't read it back from the
+	// interface. Preserve whatever the configuration already says for each
+	// subnet rather than losing the setting on every refresh.
+	defaultGatewayBySubnet := map[string]bool{}
+	for _, v := range d.Get("link").(*schema.Set).List() {
+		link := v.(map[string]any)
+
+		subnet, err := getSubnet(client, link["subnet"].(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		defaultGatewayBySubnet[fmt.Sprintf("%v", subnet.ID)] = link["default_gateway"].(bool)
+	}
+
+	links := make([]map[string]any, len(networkInterface.Links))
+	for i, link := range networkInterface.Links {
+		subnetID := fmt.Sprintf("%v", link.Subnet.ID)
+		links[i] = map[string]any{
+			"subnet":          subnetID,
+			"mode":            link.Mode,
+			"ip_address":      link.IPAddress,
+			"default_gateway": defaultGatewayBySubnet[subnetID],
+		}
+	}
+
+	if err := d.Set("link", links); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceNetworkInterfaceLinksUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	networkInterface, err := getNetworkInterface(client, systemID, d.Get("network_interface").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := reconcileNetworkInterfaceLinks(client, systemID, networkInterface, d); diags != nil {
+		return diags
+	}
+
+	return resourceNetworkInterfaceLinksRead(ctx, d, meta)
+}
+
+func resourceNetworkInterfaceLinksDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	networkInterface, err := getNetworkInterface(client, systemID, d.Get("network_interface").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policy, waitTimeout := getUnlinkPolicy(d)
+
+	for _, link := range networkInterface.Links {
+		if err := unlinkSubnet(client, systemID, networkInterface.ID, link.ID, policy, waitTimeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// reconcileNetworkInterfaceLinks diffs the desired "link" set against the
+// network interface's current links and issues the minimal set of
+// LinkSubnet/UnlinkSubnet calls needed to reach the desired state, instead of
+// wiping and recreating every link on each apply.
+func reconcileNetworkInterfaceLinks(client *client.Client, systemID string, networkInterface *entity.NetworkInterface, d *schema.ResourceData) diag.Diagnostics {
+	desired := d.Get("link").(*schema.Set).List()
+
+	defaultGatewayCount := 0
+	desiredBySubnetID := map[int]map[string]any{}
+
+	for _, v := range desired {
+		link := v.(map[string]any)
+
+		subnet, err := getSubnet(client, link["subnet"].(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		desiredBySubnetID[subnet.ID] = link
+
+		if link["default_gateway"].(bool) {
+			defaultGatewayCount++
+		}
+	}
+
+	if defaultGatewayCount > 1 {
+		return diag.Errorf("only one link may set default_gateway to true, got %d", defaultGatewayCount)
+	}
+
+	if defaultGatewayCount > 0 && d.Get("device").(string) != "" {
+		return diag.Errorf("default_gateway is not supported when addressing by device: MAAS tracks the default gateway at the machine level")
+	}
+
+	oldLinksRaw, _ := d.GetChange("link")
+	previousDefaultGatewaySubnet := defaultGatewaySubnet(oldLinksRaw.(*schema.Set).List())
+	desiredDefaultGatewaySubnet := defaultGatewaySubnet(desired)
+
+	policy, waitTimeout := getUnlinkPolicy(d)
+
+	currentBySubnetID := map[int]entity.NetworkInterfaceLink{}
+	for _, link := range networkInterface.Links {
+		currentBySubnetID[link.Subnet.ID] = link
+	}
+
+	// Unlink subnets that are no longer desired.
+	for subnetID, link := range currentBySubnetID {
+		if _, ok := desiredBySubnetID[subnetID]; !ok {
+			if err := unlinkSubnet(client, systemID, networkInterface.ID, link.ID, policy, waitTimeout); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	// Link subnets that are missing or whose parameters changed.
+	var defaultGatewayLinkID int
+
+	for subnetID, link := range desiredBySubnetID {
+		current, ok := currentBySubnetID[subnetID]
+		if ok && current.Mode == link["mode"].(string) && current.IPAddress == link["ip_address"].(string) {
+			if link["default_gateway"].(bool) {
+				defaultGatewayLinkID = current.ID
+			}
+
+			continue
+		}
+
+		if ok {
+			if err := unlinkSubnet(client, systemID, networkInterface.ID, current.ID, policy, waitTimeout); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		params := &entity.NetworkInterfaceLinkParams{
+			Subnet:         subnetID,
+			Mode:           link["mode"].(string),
+			DefaultGateway: link["default_gateway"].(bool),
+			IPAddress:      link["ip_address"].(string),
+		}
+
+		updated, err := client.NetworkInterface.LinkSubnet(systemID, networkInterface.ID, params)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if link["default_gateway"].(bool) {
+			for _, l := range updated.Links {
+				if l.Subnet.ID == subnetID {
+					defaultGatewayLinkID = l.ID
+
+					break
+				}
+			}
+		}
+	}
+
+	// Only touch the machine's default gateway if this apply actually changes
+	// which subnet (if any) is the default for this interface. ClearDefaultGateways
+	// operates on the whole machine, so calling it unconditionally on every apply
+	// would wipe out a default gateway set via a different interface.
+	if previousDefaultGatewaySubnet != desiredDefaultGatewaySubnet {
+		if _, err := client.Machine.ClearDefaultGateways(systemID); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if defaultGatewayLinkID != 0 {
+			if _, err := client.NetworkInterface.SetDefaultGateway(systemID, networkInterface.ID, defaultGatewayLinkID); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// defaultGatewaySubnet returns the subnet identifier of the link block with
+// default_gateway set to true, or "" if none is set, so callers can detect
+// whether an apply actually changes which subnet is the default gateway.
+func defaultGatewaySubnet(links []any) string {
+	for _, v := range links {
+		link := v.(map[string]any)
+		if link["default_gateway"].(bool) {
+			return link["subnet"].(string)
+		}
+	}
+
+	return ""
+}
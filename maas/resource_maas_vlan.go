@@ -0,0 +1,420 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/canonical/gomaasclient/client"
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceMAASVLAN() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage a MAAS VLAN.",
+		CreateContext: resourceVLANCreate,
+		ReadContext:   resourceVLANRead,
+		UpdateContext: resourceVLANUpdate,
+		DeleteContext: resourceVLANDelete,
+		CustomizeDiff: resourceVLANCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"fabric": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The fabric identifier (ID or name) the VLAN belongs to.",
+			},
+			"vid": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 4094)),
+				Description:      "The traffic segregation ID for the VLAN. Ranges from `0` to `4094`.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The VLAN name.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The VLAN description.",
+			},
+			"mtu": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1500,
+				Description: "The MTU used on the VLAN. Defaults to `1500`.",
+			},
+			"space": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The space this VLAN should be placed in.",
+			},
+			"dhcp_on": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Boolean value to enable DHCP on the VLAN. When set, `primary_rack` is required. Defaults to `false`.",
+			},
+			"primary_rack": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The system ID of the primary rack controller for the VLAN. The value is sent to MAAS as given; a hostname or FQDN is not resolved to a system ID. Required when `dhcp_on` is `true`.",
+			},
+			"secondary_rack": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The system ID of the secondary rack controller for the VLAN, used for HA DHCP. The value is sent to MAAS as given; a hostname or FQDN is not resolved to a system ID.",
+			},
+			"relay_vlan": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The identifier (ID) of the VLAN to relay DHCP for. When set, this VLAN does not run its own DHCP service and instead relays requests to the referenced VLAN.",
+			},
+			"dynamic_range": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Provisions a dynamic IP range on the VLAN's primary subnet, used by MAAS to hand out addresses during commissioning and enlistment.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the dynamic IP range.",
+						},
+						"start_ip": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+							Description:      "The first IP address of the dynamic range.",
+						},
+						"end_ip": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+							Description:      "The last IP address of the dynamic range.",
+						},
+						"comment": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A comment about the dynamic IP range.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceVLANCustomizeDiff enforces that primary_rack is set whenever
+// dhcp_on is true, matching the requirement called out in dhcp_on's
+// description. A plain RequiredWith would trigger any time dhcp_on is
+// present in config at all, including an explicit `dhcp_on = false`.
+func resourceVLANCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta any) error {
+	if diff.Get("dhcp_on").(bool) && diff.Get("primary_rack").(string) == "" {
+		return fmt.Errorf("primary_rack is required when dhcp_on is true")
+	}
+
+	return nil
+}
+
+func resourceVLANCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	fabric, err := getFabric(client, d.Get("fabric").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := getVLANParams(d)
+
+	relayVLAN, diags := getVLANRelayVLAN(d)
+	if diags != nil {
+		return diags
+	}
+
+	params.RelayVLAN = relayVLAN
+
+	vlan, err := client.VLANs.Create(fabric.ID, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%v", vlan.ID))
+
+	if diags := setVLANDynamicRange(client, vlan, d); diags != nil {
+		return diags
+	}
+
+	return resourceVLANRead(ctx, d, meta)
+}
+
+func resourceVLANRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	fabric, err := getFabric(client, d.Get("fabric").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vlan, err := getVLAN(client, fabric.ID, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tfState := map[string]any{
+		"vid":            vlan.VID,
+		"name":           vlan.Name,
+		"description":    vlan.Description,
+		"mtu":            vlan.MTU,
+		"space":          vlan.Space,
+		"dhcp_on":        vlan.DHCPOn,
+		"primary_rack":   vlan.PrimaryRack,
+		"secondary_rack": vlan.SecondaryRack,
+	}
+	if vlan.RelayVLAN != 0 {
+		tfState["relay_vlan"] = fmt.Sprintf("%v", vlan.RelayVLAN)
+	} else {
+		tfState["relay_vlan"] = ""
+	}
+
+	dynamicRange, err := readVLANDynamicRange(client, vlan.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if dynamicRange != nil {
+		tfState["dynamic_range"] = []map[string]any{
+			{
+				"id":       dynamicRange.ID,
+				"start_ip": dynamicRange.StartIP,
+				"end_ip":   dynamicRange.EndIP,
+				"comment":  dynamicRange.Comment,
+			},
+		}
+	} else {
+		tfState["dynamic_range"] = []map[string]any{}
+	}
+
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceVLANUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	fabric, err := getFabric(client, d.Get("fabric").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := getVLANParams(d)
+
+	relayVLAN, diags := getVLANRelayVLAN(d)
+	if diags != nil {
+		return diags
+	}
+
+	params.RelayVLAN = relayVLAN
+
+	vlan, err := client.VLAN.Update(fabric.ID, d.Get("vid").(int), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := setVLANDynamicRange(client, vlan, d); diags != nil {
+		return diags
+	}
+
+	return resourceVLANRead(ctx, d, meta)
+}
+
+func resourceVLANDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	fabric, err := getFabric(client, d.Get("fabric").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := client.VLAN.Delete(fabric.ID, d.Get("vid").(int)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getVLANParams(d *schema.ResourceData) *entity.VLANParams {
+	params := &entity.VLANParams{
+		VID:         d.Get("vid").(int),
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		MTU:         d.Get("mtu").(int),
+		Space:       d.Get("space").(string),
+		DHCPOn:      d.Get("dhcp_on").(bool),
+	}
+
+	if v, ok := d.GetOk("primary_rack"); ok {
+		params.PrimaryRack = v.(string)
+	}
+
+	if v, ok := d.GetOk("secondary_rack"); ok {
+		params.SecondaryRack = v.(string)
+	}
+
+	return params
+}
+
+// getVLANRelayVLAN resolves the relay_vlan attribute to a VLAN ID, returning a
+// diagnostic (rather than silently dropping the setting) if it's set but
+// can't be parsed.
+func getVLANRelayVLAN(d *schema.ResourceData) (int, diag.Diagnostics) {
+	v, ok := d.GetOk("relay_vlan")
+	if !ok {
+		return 0, nil
+	}
+
+	relayVLAN, err := strconv.Atoi(v.(string))
+	if err != nil {
+		return 0, diag.FromErr(fmt.Errorf("invalid relay_vlan %q: must be a numeric VLAN ID: %w", v.(string), err))
+	}
+
+	return relayVLAN, nil
+}
+
+// setVLANDynamicRange reconciles the optional dynamic_range block against the
+// VLAN's primary subnet: it creates the range if none exists yet, updates it
+// in place if start_ip/end_ip/comment changed, and removes any dynamic range
+// found on that subnet whenever no dynamic_range block is configured, whether
+// or not this resource created it. Looking up any existing
+// range first (rather than always creating) means re-applying config that
+// hasn't changed the range doesn't create a duplicate every time.
+func setVLANDynamicRange(client *client.Client, vlan *entity.VLAN, d *schema.ResourceData) diag.Diagnostics {
+	ranges := d.Get("dynamic_range").([]any)
+
+	subnet, err := getVLANPrimarySubnet(client, vlan.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(ranges) == 0 {
+		if subnet == nil {
+			return nil
+		}
+
+		existing, err := getSubnetDynamicRange(client, subnet.ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if existing == nil {
+			return nil
+		}
+
+		if err := client.IPRange.Delete(existing.ID); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+
+	if subnet == nil {
+		return diag.Errorf("cannot provision dynamic_range: VLAN (%v) has no subnet attached", vlan.ID)
+	}
+
+	dynamicRange := ranges[0].(map[string]any)
+
+	params := &entity.IPRangeParams{
+		Type:    "dynamic",
+		StartIP: dynamicRange["start_ip"].(string),
+		EndIP:   dynamicRange["end_ip"].(string),
+		Subnet:  subnet.ID,
+		Comment: dynamicRange["comment"].(string),
+	}
+
+	existing, err := getSubnetDynamicRange(client, subnet.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if existing == nil {
+		if _, err := client.IPRanges.Create(params); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+
+	if existing.StartIP == params.StartIP && existing.EndIP == params.EndIP && existing.Comment == params.Comment {
+		return nil
+	}
+
+	if _, err := client.IPRange.Update(existing.ID, params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// readVLANDynamicRange returns the dynamic IP range provisioned on the VLAN's
+// primary subnet, or nil if the VLAN has no subnet or no dynamic range.
+func readVLANDynamicRange(client *client.Client, vlanID int) (*entity.IPRange, error) {
+	subnet, err := getVLANPrimarySubnet(client, vlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if subnet == nil {
+		return nil, nil
+	}
+
+	return getSubnetDynamicRange(client, subnet.ID)
+}
+
+// getSubnetDynamicRange returns the first dynamic IP range on the given
+// subnet, or nil if none is provisioned.
+func getSubnetDynamicRange(client *client.Client, subnetID int) (*entity.IPRange, error) {
+	ranges, err := client.IPRanges.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range ranges {
+		if ranges[i].Subnet.ID == subnetID && ranges[i].Type == "dynamic" {
+			return &ranges[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getVLANPrimarySubnet returns the first subnet attached to the given VLAN,
+// used as the target for the VLAN resource's dynamic_range block. It returns
+// a nil subnet (not an error) when the VLAN has no subnet attached, since
+// that's a normal state for a VLAN with no dynamic_range configured.
+func getVLANPrimarySubnet(client *client.Client, vlanID int) (*entity.Subnet, error) {
+	subnets, err := client.Subnets.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range subnets {
+		if subnets[i].VLAN.ID == vlanID {
+			return &subnets[i], nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,199 @@
+package maas
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/canonical/gomaasclient/entity"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceMAASVLANInterface() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provides a resource to manage a MAAS VLAN (802.1Q) sub-interface on a machine or device. The resulting interface is named after the `<parent>.<vid>` convention, e.g. `eth0.42`.",
+		CreateContext: resourceVLANInterfaceCreate,
+		ReadContext:   resourceVLANInterfaceRead,
+		UpdateContext: resourceVLANInterfaceUpdate,
+		DeleteContext: resourceVLANInterfaceDelete,
+
+		Schema: map[string]*schema.Schema{
+			"device": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"machine", "device"},
+				Description:  "The identifier (system ID, hostname, or FQDN) of the device with the parent network interface. Either `machine` or `device` must be provided.",
+			},
+			"machine": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"machine", "device"},
+				Description:  "The identifier (system ID, hostname, or FQDN) of the machine with the parent network interface. Either `machine` or `device` must be provided.",
+			},
+			"parent": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier (MAC address, name, or ID) of the parent network interface. May be a physical, bond, or bridge interface.",
+			},
+			"fabric": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The fabric identifier (ID or name) for the VLAN.",
+			},
+			"vlan": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The VLAN identifier (ID or traffic segregation ID) to create the sub-interface on.",
+			},
+			"mtu": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1500,
+				Description: "The MTU of the VLAN interface. Defaults to `1500`.",
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of tags to assign to the VLAN interface.",
+			},
+			"accept_ra": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Boolean value indicating if the interface should accept IPv6 router advertisements. Defaults to `true`.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the VLAN interface, in the `<parent>.<vid>` convention (e.g. `eth0.42`).",
+			},
+		},
+	}
+}
+
+func resourceVLANInterfaceCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	parent, err := getNetworkInterface(client, systemID, d.Get("parent").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	fabric, err := getFabric(client, d.Get("fabric").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vlan, err := getVLAN(client, fabric.ID, d.Get("vlan").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	networkInterface, err := client.NetworkInterfaces.CreateVLAN(systemID, getVLANInterfaceParams(d, parent.ID, vlan.ID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%v", networkInterface.ID))
+
+	return resourceVLANInterfaceRead(ctx, d, meta)
+}
+
+func resourceVLANInterfaceRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	networkInterface, err := getNetworkInterface(client, systemID, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tfState := map[string]any{
+		"name":      networkInterface.Name,
+		"mtu":       networkInterface.EffectiveMTU,
+		"tags":      networkInterface.Tags,
+		"accept_ra": networkInterface.AcceptRA,
+	}
+	if err := setTerraformState(d, tfState); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceVLANInterfaceUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	interfaceID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := &entity.NetworkInterfaceUpdateParams{
+		MTU:      d.Get("mtu").(int),
+		Tags:     getStringList(d.Get("tags").([]any)),
+		AcceptRA: d.Get("accept_ra").(bool),
+	}
+
+	if _, err := client.NetworkInterface.Update(systemID, interfaceID, params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceVLANInterfaceRead(ctx, d, meta)
+}
+
+func resourceVLANInterfaceDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*ClientConfig).Client
+
+	systemID, err := getMachineOrDeviceSystemID(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	interfaceID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Releasing the machine already tears down any VLAN sub-interfaces MAAS
+	// created on it, so treat a missing interface as a successful delete
+	// rather than surfacing a 404 from a stale machine.
+	if _, err := getNetworkInterface(client, systemID, d.Id()); err != nil {
+		return nil //nolint:nilerr // Interface (and likely the machine) is already gone.
+	}
+
+	if err := client.NetworkInterface.Delete(systemID, interfaceID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func getVLANInterfaceParams(d *schema.ResourceData, parentID int, vlanID int) *entity.NetworkInterfaceVLANParams {
+	return &entity.NetworkInterfaceVLANParams{
+		VLAN:     vlanID,
+		Parent:   parentID,
+		MTU:      d.Get("mtu").(int),
+		Tags:     getStringList(d.Get("tags").([]any)),
+		AcceptRA: d.Get("accept_ra").(bool),
+	}
+}